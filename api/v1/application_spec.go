@@ -0,0 +1,106 @@
+package v1
+
+// ApplicationSpec describes every version of a marketplace application that
+// can be installed onto a mesh.
+type ApplicationSpec struct {
+	Name     string
+	Versions []*VersionedApplicationSpec
+}
+
+// VersionedApplicationSpec is a single installable version of an
+// application: the set of flavors it can be rendered as, the layers that
+// make up its resources, and the default values used to render them.
+type VersionedApplicationSpec struct {
+	Version string
+
+	// ValuesYaml holds the spec-defined default values, merged as the
+	// lowest-precedence layer when rendering.
+	ValuesYaml string
+
+	Flavors map[string]*Flavor
+
+	// Layers maps a LayerId to the set of selectable options that make it
+	// up, e.g. LayerId "custom-resources" might have options "create" and
+	// "skip".
+	Layers map[string]*Layer
+
+	// Labels and Annotations are applied to every resource rendered from
+	// this version, at the lowest precedence (a Flavor or the install's
+	// user-supplied inputs may override them).
+	Labels      map[string]string
+	Annotations map[string]string
+}
+
+// Flavor is a named bundle of values and layer selections that together
+// produce a complete, renderable set of resources for an application
+// version.
+type Flavor struct {
+	Name string
+
+	// ValuesYaml holds the flavor-defined default values, merged above the
+	// spec-defined values and below anything the user supplies.
+	ValuesYaml string
+
+	Layers []LayerSelection
+
+	// Labels and Annotations are applied to every resource rendered from
+	// this flavor, above the version's own Labels/Annotations and below
+	// anything the install's user-supplied inputs provide.
+	Labels      map[string]string
+	Annotations map[string]string
+
+	// Source, if set, additionally renders resources from a Helm chart,
+	// merged with whatever the flavor's Layers produce. Nil means the
+	// flavor is backed only by its Layers, as before.
+	Source *FlavorSource
+}
+
+// FlavorSource names an external source of resources for a Flavor. Exactly
+// one field is set.
+type FlavorSource struct {
+	Helm *HelmChartSource
+}
+
+// HelmChartSource locates a Helm chart to render as part of a flavor,
+// either from a chart repository or an OCI registry.
+type HelmChartSource struct {
+	// RepoURL and ChartName locate the chart in a classic Helm chart
+	// repository; Version pins the chart version. RepoURL and ChartName
+	// are ignored if OCIRef is set; Version is passed to the chart
+	// downloader either way.
+	RepoURL   string
+	ChartName string
+	Version   string
+
+	// OCIRef is a full OCI reference (e.g.
+	// "oci://ghcr.io/solo-io/charts/gloo:1.2.3"). Takes precedence over
+	// RepoURL/ChartName if set.
+	OCIRef string
+}
+
+// LayerSelection pins a single layer to one of its options for a given
+// flavor.
+type LayerSelection struct {
+	LayerId  string
+	OptionId string
+}
+
+// Layer is a named, versioned unit of resources that can be toggled between
+// mutually exclusive options (e.g. "enabled" vs "disabled").
+type Layer struct {
+	Options map[string]*LayerOption
+}
+
+// LayerOption is one of the mutually exclusive choices for a Layer.
+type LayerOption struct {
+	// ManifestTemplate is a Go-template-rendered manifest of one or more
+	// Kubernetes resources. It is rendered for every selected layer, also
+	// when the flavor has a Source.
+	ManifestTemplate string
+
+	// ValuesYaml, when set, is merged into the values used to render both
+	// the layers' ManifestTemplates and, for a Helm-sourced flavor, its
+	// chart: above the chart's own defaults and below
+	// UserDefinedValues/Params.
+	ValuesYaml string
+}
@@ -0,0 +1,7 @@
+package v1
+
+import "fmt"
+
+func formatDate(year, month, day int) string {
+	return fmt.Sprintf("%04d-%02d-%02d", year, month, day)
+}
@@ -0,0 +1,105 @@
+package v1
+
+// ParameterValue is the resolved value of a single application parameter.
+// Exactly one of the Type fields is set.
+type ParameterValue struct {
+	Type isParameterValue_Type
+}
+
+func (m *ParameterValue) GetType() isParameterValue_Type {
+	if m != nil {
+		return m.Type
+	}
+	return nil
+}
+
+type isParameterValue_Type interface {
+	isParameterValue_Type()
+}
+
+type ParameterValue_BooleanValue struct {
+	BooleanValue bool
+}
+
+type ParameterValue_DateValue struct {
+	DateValue *DateValue
+}
+
+type ParameterValue_FloatValue struct {
+	FloatValue float64
+}
+
+type ParameterValue_IntValue struct {
+	IntValue int64
+}
+
+type ParameterValue_SecretValue struct {
+	SecretValue *SecretValue
+}
+
+type ParameterValue_StringValue struct {
+	StringValue string
+}
+
+func (*ParameterValue_BooleanValue) isParameterValue_Type() {}
+func (*ParameterValue_DateValue) isParameterValue_Type()    {}
+func (*ParameterValue_FloatValue) isParameterValue_Type()   {}
+func (*ParameterValue_IntValue) isParameterValue_Type()     {}
+func (*ParameterValue_SecretValue) isParameterValue_Type()  {}
+func (*ParameterValue_StringValue) isParameterValue_Type()  {}
+
+// DateValue is a minimal date wrapper so ParameterValue_DateValue can be
+// formatted without pulling in a full timestamp dependency.
+type DateValue struct {
+	Year, Month, Day int
+}
+
+func (d *DateValue) String() string {
+	if d == nil {
+		return ""
+	}
+	return formatDate(d.Year, d.Month, d.Day)
+}
+
+// SecretValue references a secret value held in an external backend. Exactly
+// one of the Ref fields is set.
+type SecretValue struct {
+	Ref isSecretValue_Ref
+}
+
+func (m *SecretValue) GetRef() isSecretValue_Ref {
+	if m != nil {
+		return m.Ref
+	}
+	return nil
+}
+
+type isSecretValue_Ref interface {
+	isSecretValue_Ref()
+}
+
+// SecretValue_Kubernetes resolves the secret from a Kubernetes Secret's
+// data[Key], in the given Namespace/Name.
+type SecretValue_Kubernetes struct {
+	Kubernetes *KubernetesSecretRef
+}
+
+// SecretValue_Vault resolves the secret from a HashiCorp Vault secret at
+// Path, reading the Field key from its data.
+type SecretValue_Vault struct {
+	Vault *VaultSecretRef
+}
+
+func (*SecretValue_Kubernetes) isSecretValue_Ref() {}
+func (*SecretValue_Vault) isSecretValue_Ref()      {}
+
+type KubernetesSecretRef struct {
+	Namespace string
+	Name      string
+	Key       string
+}
+
+type VaultSecretRef struct {
+	Path  string
+	Field string
+}
@@ -0,0 +1,85 @@
+//go:build e2e
+// +build e2e
+
+// Package e2e installs the gloo extension onto a real cluster and asserts
+// its resources actually come up, as a companion to the in-process
+// assertions in extensions/v1/gloo/test. Run with `go test -tags e2e ./...`.
+package e2e
+
+import (
+	"context"
+	"testing"
+
+	v1 "github.com/solo-io/service-mesh-hub/api/v1"
+	"github.com/solo-io/service-mesh-hub/pkg/render"
+	"github.com/solo-io/service-mesh-hub/test"
+	"github.com/solo-io/service-mesh-hub/test/e2e"
+	"github.com/solo-io/service-mesh-hub/test/e2e/helper"
+	"github.com/solo-io/solo-kit/pkg/api/v1/resources/core"
+)
+
+func TestGlooInstall(t *testing.T) {
+	ctx := context.Background()
+
+	cluster, err := e2e.NewTestCluster("gloo-install")
+	if err != nil {
+		t.Fatalf("starting test cluster: %v", err)
+	}
+	defer cluster.Stop()
+
+	namespace, err := cluster.CreateIsolatedNamespace(ctx, "gloo-system")
+	if err != nil {
+		t.Fatalf("creating isolated namespace: %v", err)
+	}
+	defer cluster.DeleteNamespace(ctx, namespace)
+
+	if err := cluster.EnableIstioSidecarInjection(ctx, namespace); err != nil {
+		t.Fatalf("enabling sidecar injection: %v", err)
+	}
+
+	spec := test.LoadApplicationSpec("../../spec.yaml")
+	var version *v1.VersionedApplicationSpec
+	for _, v := range spec.Versions {
+		if v.Version == "0.18.35" {
+			version = v
+		}
+	}
+	if version == nil {
+		t.Fatal("fixture spec.yaml has no version 0.18.35")
+	}
+
+	inputs := render.ValuesInputs{
+		Name:   "gloo",
+		Flavor: test.GetFlavor("vanilla", version),
+		Layers: []render.LayerInput{{LayerId: "custom-resources", OptionId: "create"}},
+		MeshRef: core.ResourceRef{
+			Name:      "istio",
+			Namespace: namespace,
+		},
+		SpecDefinedValues: version.ValuesYaml,
+		Params:            map[string]string{"apiServer.enable": "true"},
+	}
+	inputs = cluster.ForRealCluster(inputs, namespace)
+
+	result, err := render.ComputeResourcesForApplication(ctx, inputs, version)
+	if err != nil {
+		t.Fatalf("rendering gloo resources: %v", err)
+	}
+
+	mapper, err := cluster.RESTMapper()
+	if err != nil {
+		t.Fatalf("building REST mapper: %v", err)
+	}
+	installation := helper.NewInstallation(cluster.Dynamic, mapper, namespace)
+	if err := installation.Apply(ctx, result.Resources); err != nil {
+		t.Fatalf("applying gloo resources: %v", err)
+	}
+	defer installation.Teardown(ctx)
+
+	if err := cluster.AssertDeploymentReady(ctx, namespace, "gloo"); err != nil {
+		t.Fatalf("gloo deployment never became ready: %v", err)
+	}
+	if err := cluster.AssertServiceEndpointsReady(ctx, namespace, "gloo"); err != nil {
+		t.Fatalf("gloo service never had ready endpoints: %v", err)
+	}
+}
@@ -1,6 +1,7 @@
 package test
 
 import (
+	"bytes"
 	"context"
 
 	. "github.com/onsi/ginkgo"
@@ -10,8 +11,45 @@ import (
 	"github.com/solo-io/service-mesh-hub/pkg/render"
 	"github.com/solo-io/service-mesh-hub/test"
 	"github.com/solo-io/solo-kit/pkg/api/v1/resources/core"
+	"helm.sh/helm/v3/pkg/chart"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
 )
 
+// fakeChartLoader serves a single, pre-built chart regardless of the
+// HelmChartSource it's asked to load, so tests don't depend on a real chart
+// repository or registry.
+type fakeChartLoader struct {
+	chart *chart.Chart
+}
+
+func (f *fakeChartLoader) Load(_ context.Context, _ *v1.HelmChartSource) (*chart.Chart, error) {
+	return f.chart, nil
+}
+
+// chartFromManifest builds a minimal chart whose single template is a
+// literal dump of an already-rendered manifest, so it reproduces the exact
+// same resources when rendered.
+func chartFromManifest(resources []*unstructured.Unstructured) (*chart.Chart, error) {
+	var docs [][]byte
+	for _, resource := range resources {
+		doc, err := yaml.Marshal(resource.Object)
+		if err != nil {
+			return nil, err
+		}
+		docs = append(docs, doc)
+	}
+
+	manifest := bytes.Join(docs, []byte("---\n"))
+	return &chart.Chart{
+		Metadata: &chart.Metadata{Name: "gloo", Version: "0.18.35"},
+		Templates: []*chart.File{{
+			Name: "templates/manifest.yaml",
+			Data: manifest,
+		}},
+	}, nil
+}
+
 var _ = Describe("gloo extension test", func() {
 
 	const (
@@ -70,20 +108,54 @@ var _ = Describe("gloo extension test", func() {
 
 				It("has the correct number of resources with apiserver enabled", func() {
 					inputs.Params = map[string]string{"apiServer.enable": "true"}
-					rendered, err := render.ComputeResourcesForApplication(context.TODO(), inputs, version)
+					result, err := render.ComputeResourcesForApplication(context.TODO(), inputs, version)
 					Expect(err).NotTo(HaveOccurred())
-					testManifest = NewTestManifestWithResources(rendered)
+					testManifest = NewTestManifestWithResources(result.Resources)
 					Expect(testManifest.NumResources()).To(Equal(36))
 				})
 
 				It("has the correct number of resources with apiserver disabled", func() {
 					inputs.Params = map[string]string{"apiServer.enable": "false"}
-					rendered, err := render.ComputeResourcesForApplication(context.TODO(), inputs, version)
+					result, err := render.ComputeResourcesForApplication(context.TODO(), inputs, version)
 					Expect(err).NotTo(HaveOccurred())
-					testManifest = NewTestManifestWithResources(rendered)
+					testManifest = NewTestManifestWithResources(result.Resources)
 					Expect(testManifest.NumResources()).To(Equal(31))
 				})
 			})
+
+			Context("with a Helm-sourced equivalent of the packaged flavor", func() {
+				It("renders the same number of resources as the packaged flavor", func() {
+					version = versionMap["0.18.35"]
+					layers := []render.LayerInput{{
+						LayerId:  "custom-resources",
+						OptionId: "create",
+					}}
+					inputs = testInput("vanilla", layers)
+					inputs.Params = map[string]string{"apiServer.enable": "true"}
+
+					packaged, err := render.ComputeResourcesForApplication(context.TODO(), inputs, version)
+					Expect(err).NotTo(HaveOccurred())
+
+					helmChart, err := chartFromManifest(packaged.Resources)
+					Expect(err).NotTo(HaveOccurred())
+
+					helmInputs := inputs
+					helmInputs.Layers = nil
+					helmInputs.Flavor = &v1.Flavor{
+						Name: "vanilla-helm",
+						Source: &v1.FlavorSource{
+							Helm: &v1.HelmChartSource{ChartName: "gloo", Version: "0.18.35"},
+						},
+					}
+					helmInputs.ChartLoader = &fakeChartLoader{chart: helmChart}
+
+					result, err := render.ComputeResourcesForApplication(context.TODO(), helmInputs, version)
+					Expect(err).NotTo(HaveOccurred())
+
+					testManifest = NewTestManifestWithResources(result.Resources)
+					Expect(testManifest.NumResources()).To(Equal(len(packaged.Resources)))
+				})
+			})
 		})
 		Context("with custom flavor", func() {
 			BeforeEach(func() {
@@ -101,9 +173,9 @@ var _ = Describe("gloo extension test", func() {
 					UserDefinedValues: "gateway:\n  upgrade: true",
 					SpecDefinedValues: version.ValuesYaml,
 				}
-				rendered, err := render.ComputeResourcesForApplication(context.TODO(), inputs, version)
+				result, err := render.ComputeResourcesForApplication(context.TODO(), inputs, version)
 				Expect(err).NotTo(HaveOccurred())
-				testManifest = NewTestManifestWithResources(rendered)
+				testManifest = NewTestManifestWithResources(result.Resources)
 			})
 
 			It("has the correct number of resources with gateway upgrade enabled", func() {
@@ -114,6 +186,48 @@ var _ = Describe("gloo extension test", func() {
 				testManifest.Expect("Job", "gloo-system", "gateway-conversion").NotTo(BeNil())
 			})
 		})
+
+		Context("with user-supplied labels and annotations", func() {
+			var result *render.RenderResult
+
+			BeforeEach(func() {
+				version = versionMap["0.18.35"]
+				layers := []render.LayerInput{{
+					LayerId:  "custom-resources",
+					OptionId: "create",
+				}}
+				inputs = testInput("vanilla", layers)
+				inputs.Params = map[string]string{"apiServer.enable": "true"}
+				inputs.Labels = map[string]string{"team": "service-mesh"}
+				inputs.Annotations = map[string]string{"solo.io/owner": "sm-marketplace"}
+
+				var err error
+				result, err = render.ComputeResourcesForApplication(context.TODO(), inputs, version)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("propagates labels and annotations to every resource", func() {
+				for _, resource := range result.Resources {
+					Expect(resource.GetLabels()).To(HaveKeyWithValue("team", "service-mesh"))
+					Expect(resource.GetAnnotations()).To(HaveKeyWithValue("solo.io/owner", "sm-marketplace"))
+				}
+			})
+
+			It("propagates labels and annotations to nested pod templates", func() {
+				for _, resource := range result.Resources {
+					if resource.GetKind() != "Deployment" {
+						continue
+					}
+					podLabels, _, err := unstructured.NestedStringMap(resource.Object, "spec", "template", "metadata", "labels")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(podLabels).To(HaveKeyWithValue("team", "service-mesh"))
+
+					podAnnotations, _, err := unstructured.NestedStringMap(resource.Object, "spec", "template", "metadata", "annotations")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(podAnnotations).To(HaveKeyWithValue("solo.io/owner", "sm-marketplace"))
+				}
+			})
+		})
 	})
 
 })
@@ -0,0 +1,39 @@
+package render
+
+import (
+	"context"
+	"fmt"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+)
+
+// CloudEventsHTTPSink emits render lifecycle events as CloudEvents v1.0
+// structured-mode JSON over HTTP.
+type CloudEventsHTTPSink struct {
+	client cloudevents.Client
+}
+
+// NewCloudEventsHTTPSink builds a sink that POSTs CloudEvents to target as
+// structured-mode JSON.
+func NewCloudEventsHTTPSink(target string) (*CloudEventsHTTPSink, error) {
+	client, err := cloudevents.NewClientHTTP(
+		cloudevents.WithTarget(target),
+		cloudevents.WithEncoding(cloudevents.EncodingStructured),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("building cloudevents client: %w", err)
+	}
+	return &CloudEventsHTTPSink{client: client}, nil
+}
+
+func (s *CloudEventsHTTPSink) Emit(ctx context.Context, event cloudevents.Event) error {
+	result := s.client.Send(ctx, event)
+	// IsUndelivered only covers transport-level failures; a completed round
+	// trip that NACKs (e.g. a transient 5xx/429 from the receiver) must also
+	// be treated as retryable, so emitEvent's backoff loop actually retries
+	// it instead of the sink silently swallowing it.
+	if !cloudevents.IsACK(result) {
+		return fmt.Errorf("delivering event %s: %w", event.ID(), result)
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package render_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/solo-io/service-mesh-hub/pkg/render"
+)
+
+func newTestEvent() cloudevents.Event {
+	event := cloudevents.NewEvent()
+	event.SetID("test-event")
+	event.SetSource("test")
+	event.SetType("test.event")
+	_ = event.SetData(cloudevents.ApplicationJSON, map[string]string{"k": "v"})
+	return event
+}
+
+func TestCloudEventsHTTPSink_Emit_ErrorsOnServerNack(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	sink, err := render.NewCloudEventsHTTPSink(server.URL)
+	if err != nil {
+		t.Fatalf("building sink: %v", err)
+	}
+
+	if err := sink.Emit(context.TODO(), newTestEvent()); err == nil {
+		t.Fatal("expected an error for a 503 response, got nil")
+	}
+}
+
+func TestCloudEventsHTTPSink_Emit_SucceedsOnAck(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	sink, err := render.NewCloudEventsHTTPSink(server.URL)
+	if err != nil {
+		t.Fatalf("building sink: %v", err)
+	}
+
+	if err := sink.Emit(context.TODO(), newTestEvent()); err != nil {
+		t.Fatalf("unexpected error for a 200 response: %v", err)
+	}
+}
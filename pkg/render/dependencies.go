@@ -0,0 +1,127 @@
+package render
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// computeDependencyEdges infers the dependency graph between rendered
+// resources from well-known Kubernetes conventions: a Deployment/
+// StatefulSet/DaemonSet/Job depends on the ServiceAccount its pods run as,
+// a Service depends on the workloads its selector matches, and a Job
+// depends on the ConfigMaps it mounts or reads env from.
+func computeDependencyEdges(resources []*unstructured.Unstructured, idsByObject map[*unstructured.Unstructured]string) []DependencyEdge {
+	var edges []DependencyEdge
+
+	serviceAccountsByKey := indexByNamespacedName(resources, "ServiceAccount")
+	configMapsByKey := indexByNamespacedName(resources, "ConfigMap")
+
+	for _, obj := range resources {
+		switch obj.GetKind() {
+		case "Deployment", "StatefulSet", "DaemonSet", "Job":
+			if saName := podTemplateServiceAccountName(obj); saName != "" {
+				if target, ok := serviceAccountsByKey[namespacedName{obj.GetNamespace(), saName}]; ok {
+					edges = append(edges, DependencyEdge{From: idsByObject[obj], To: idsByObject[target]})
+				}
+			}
+		}
+		if obj.GetKind() == "Job" {
+			for _, cmName := range podTemplateConfigMapNames(obj) {
+				if target, ok := configMapsByKey[namespacedName{obj.GetNamespace(), cmName}]; ok {
+					edges = append(edges, DependencyEdge{From: idsByObject[obj], To: idsByObject[target]})
+				}
+			}
+		}
+		if obj.GetKind() == "Service" {
+			selector, _, _ := unstructured.NestedStringMap(obj.Object, "spec", "selector")
+			if len(selector) == 0 {
+				continue
+			}
+			for _, candidate := range resources {
+				if !isWorkloadKind(candidate.GetKind()) || candidate.GetNamespace() != obj.GetNamespace() {
+					continue
+				}
+				podLabels, _, _ := unstructured.NestedStringMap(candidate.Object, "spec", "template", "metadata", "labels")
+				if matchesSelector(podLabels, selector) {
+					edges = append(edges, DependencyEdge{From: idsByObject[obj], To: idsByObject[candidate]})
+				}
+			}
+		}
+	}
+
+	return edges
+}
+
+type namespacedName struct {
+	namespace string
+	name      string
+}
+
+func indexByNamespacedName(resources []*unstructured.Unstructured, kind string) map[namespacedName]*unstructured.Unstructured {
+	index := map[namespacedName]*unstructured.Unstructured{}
+	for _, obj := range resources {
+		if obj.GetKind() == kind {
+			index[namespacedName{obj.GetNamespace(), obj.GetName()}] = obj
+		}
+	}
+	return index
+}
+
+func isWorkloadKind(kind string) bool {
+	switch kind {
+	case "Deployment", "StatefulSet", "DaemonSet":
+		return true
+	}
+	return false
+}
+
+func matchesSelector(labels, selector map[string]string) bool {
+	if len(labels) == 0 {
+		return false
+	}
+	for key, value := range selector {
+		if labels[key] != value {
+			return false
+		}
+	}
+	return true
+}
+
+func podTemplateServiceAccountName(obj *unstructured.Unstructured) string {
+	name, _, _ := unstructured.NestedString(obj.Object, "spec", "template", "spec", "serviceAccountName")
+	return name
+}
+
+func podTemplateConfigMapNames(obj *unstructured.Unstructured) []string {
+	var names []string
+
+	volumes, _, _ := unstructured.NestedSlice(obj.Object, "spec", "template", "spec", "volumes")
+	for _, v := range volumes {
+		volume, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if name, _, _ := unstructured.NestedString(volume, "configMap", "name"); name != "" {
+			names = append(names, name)
+		}
+	}
+
+	containers, _, _ := unstructured.NestedSlice(obj.Object, "spec", "template", "spec", "containers")
+	for _, c := range containers {
+		container, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		envFrom, _, _ := unstructured.NestedSlice(container, "envFrom")
+		for _, ef := range envFrom {
+			source, ok := ef.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			if name, _, _ := unstructured.NestedString(source, "configMapRef", "name"); name != "" {
+				names = append(names, name)
+			}
+		}
+	}
+
+	return names
+}
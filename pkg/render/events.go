@@ -0,0 +1,113 @@
+package render
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+)
+
+const (
+	eventTypeRenderStarted   = "com.solo.sm-marketplace.render.started"
+	eventTypeRenderCompleted = "com.solo.sm-marketplace.render.completed"
+	eventTypeRenderFailed    = "com.solo.sm-marketplace.render.failed"
+
+	eventSource = "service-mesh-hub/pkg/render"
+
+	traceIDExtension = "traceid"
+)
+
+// EventSink observes the render/install lifecycle by receiving CloudEvents.
+// Implementations must be safe for concurrent use.
+type EventSink interface {
+	Emit(ctx context.Context, event cloudevents.Event) error
+}
+
+// NoOpEventSink discards every event. It is the default EventSink when
+// ValuesInputs.EventSink is unset.
+type NoOpEventSink struct{}
+
+func (NoOpEventSink) Emit(context.Context, cloudevents.Event) error { return nil }
+
+// renderEventData is the CloudEvents data payload for render lifecycle
+// events.
+type renderEventData struct {
+	ResourceCount    int      `json:"resourceCount"`
+	ValidationErrors []string `json:"validationErrors,omitempty"`
+}
+
+func eventSink(inputs ValuesInputs) EventSink {
+	if inputs.EventSink == nil {
+		return NoOpEventSink{}
+	}
+	return inputs.EventSink
+}
+
+// newRenderEvent builds a lifecycle CloudEvent for the given install,
+// propagating the trace ID carried on ctx (if any) as a CloudEvents
+// extension attribute so downstream consumers can correlate it with the
+// originating request.
+func newRenderEvent(ctx context.Context, eventType string, inputs ValuesInputs, version string, data renderEventData, validationErr error) cloudevents.Event {
+	event := cloudevents.NewEvent()
+	event.SetID(fmt.Sprintf("%s-%s-%d", inputs.Name, eventType, time.Now().UnixNano()))
+	event.SetSource(eventSource)
+	event.SetType(eventType)
+	event.SetTime(time.Now())
+
+	event.SetExtension("application", inputs.Name)
+	event.SetExtension("version", version)
+	if inputs.Flavor != nil {
+		event.SetExtension("flavor", inputs.Flavor.Name)
+	}
+	event.SetExtension("meshref", fmt.Sprintf("%s/%s", inputs.MeshRef.Namespace, inputs.MeshRef.Name))
+	event.SetExtension("installnamespace", inputs.InstallNamespace)
+	if traceID := traceIDFromContext(ctx); traceID != "" {
+		event.SetExtension(traceIDExtension, traceID)
+	}
+
+	if validationErr != nil {
+		data.ValidationErrors = append(data.ValidationErrors, validationErr.Error())
+	}
+	_ = event.SetData(cloudevents.ApplicationJSON, data)
+
+	return event
+}
+
+type traceIDKey struct{}
+
+// ContextWithTraceID returns a context carrying traceID, which will be
+// propagated onto any render lifecycle events emitted while rendering with
+// it.
+func ContextWithTraceID(ctx context.Context, traceID string) context.Context {
+	return context.WithValue(ctx, traceIDKey{}, traceID)
+}
+
+func traceIDFromContext(ctx context.Context) string {
+	traceID, _ := ctx.Value(traceIDKey{}).(string)
+	return traceID
+}
+
+// emitEvent sends event to sink, retrying transient failures with
+// exponential backoff. It never returns an error to the caller: a sink that
+// cannot be reached should not fail an otherwise-successful render.
+func emitEvent(ctx context.Context, sink EventSink, event cloudevents.Event) {
+	const (
+		maxAttempts = 3
+		baseDelay   = 100 * time.Millisecond
+	)
+
+	var err error
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		if err = sink.Emit(ctx, event); err == nil {
+			return
+		}
+		if attempt < maxAttempts-1 {
+			select {
+			case <-time.After(baseDelay * time.Duration(1<<attempt)):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package render_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	v1 "github.com/solo-io/service-mesh-hub/api/v1"
+	"github.com/solo-io/service-mesh-hub/pkg/render"
+)
+
+// inMemoryEventSink records every event it receives, for use in tests.
+type inMemoryEventSink struct {
+	mu     sync.Mutex
+	events []cloudevents.Event
+}
+
+func (s *inMemoryEventSink) Emit(_ context.Context, event cloudevents.Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.events = append(s.events, event)
+	return nil
+}
+
+func (s *inMemoryEventSink) Types() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var types []string
+	for _, event := range s.events {
+		types = append(types, event.Type())
+	}
+	return types
+}
+
+func TestComputeResourcesForApplication_EmitsStartedAndCompleted(t *testing.T) {
+	sink := &inMemoryEventSink{}
+	version := &v1.VersionedApplicationSpec{
+		Version: "1.0.0",
+		Layers: map[string]*v1.Layer{
+			"custom-resources": {
+				Options: map[string]*v1.LayerOption{
+					"create": {ManifestTemplate: ""},
+				},
+			},
+		},
+	}
+	inputs := render.ValuesInputs{
+		Name: "gloo",
+		Layers: []render.LayerInput{{
+			LayerId:  "custom-resources",
+			OptionId: "create",
+		}},
+		EventSink: sink,
+	}
+
+	_, err := render.ComputeResourcesForApplication(context.TODO(), inputs, version)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	types := sink.Types()
+	if len(types) != 2 || types[0] != "com.solo.sm-marketplace.render.started" || types[1] != "com.solo.sm-marketplace.render.completed" {
+		t.Fatalf("unexpected event sequence: %v", types)
+	}
+}
+
+func TestComputeResourcesForApplication_EmitsFailedOnError(t *testing.T) {
+	sink := &inMemoryEventSink{}
+	version := &v1.VersionedApplicationSpec{Version: "1.0.0"}
+	inputs := render.ValuesInputs{
+		Name: "gloo",
+		Layers: []render.LayerInput{{
+			LayerId:  "missing-layer",
+			OptionId: "create",
+		}},
+		EventSink: sink,
+	}
+
+	_, err := render.ComputeResourcesForApplication(context.TODO(), inputs, version)
+	if err == nil {
+		t.Fatal("expected an error for a missing layer")
+	}
+
+	types := sink.Types()
+	if len(types) != 2 || types[1] != "com.solo.sm-marketplace.render.failed" {
+		t.Fatalf("unexpected event sequence: %v", types)
+	}
+}
+
+// flakyEventSink fails its first failures Emit calls, then succeeds, so
+// tests can assert that emitEvent's backoff loop actually retries a
+// transient sink failure rather than giving up after the first attempt.
+type flakyEventSink struct {
+	mu       sync.Mutex
+	failures int
+	attempts int
+}
+
+func (s *flakyEventSink) Emit(context.Context, cloudevents.Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.attempts++
+	if s.attempts <= s.failures {
+		return fmt.Errorf("simulated transient sink failure")
+	}
+	return nil
+}
+
+func (s *flakyEventSink) Attempts() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.attempts
+}
+
+func TestComputeResourcesForApplication_RetriesTransientSinkFailures(t *testing.T) {
+	sink := &flakyEventSink{failures: 2}
+	version := &v1.VersionedApplicationSpec{
+		Version: "1.0.0",
+		Layers: map[string]*v1.Layer{
+			"custom-resources": {
+				Options: map[string]*v1.LayerOption{
+					"create": {ManifestTemplate: ""},
+				},
+			},
+		},
+	}
+	inputs := render.ValuesInputs{
+		Name: "gloo",
+		Layers: []render.LayerInput{{
+			LayerId:  "custom-resources",
+			OptionId: "create",
+		}},
+		EventSink: sink,
+	}
+
+	_, err := render.ComputeResourcesForApplication(context.TODO(), inputs, version)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if attempts := sink.Attempts(); attempts <= sink.failures {
+		t.Fatalf("expected emitEvent to retry past %d simulated failures, got %d attempts", sink.failures, attempts)
+	}
+}
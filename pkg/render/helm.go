@@ -0,0 +1,197 @@
+package render
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	v1 "github.com/solo-io/service-mesh-hub/api/v1"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/cli"
+	"helm.sh/helm/v3/pkg/downloader"
+	"helm.sh/helm/v3/pkg/engine"
+	"helm.sh/helm/v3/pkg/getter"
+	"helm.sh/helm/v3/pkg/repo"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// ChartLoader fetches the Helm chart referenced by a HelmChartSource.
+// Implementations should be safe for concurrent use; the default
+// implementation is backed by chartCache.
+type ChartLoader interface {
+	Load(ctx context.Context, source *v1.HelmChartSource) (*chart.Chart, error)
+}
+
+// chartCache is a ChartLoader that fetches charts via Helm's downloader and
+// caches them in memory, keyed by a digest of the chart reference, so a
+// repeatedly-installed flavor doesn't re-pull the chart on every render.
+type chartCache struct {
+	mu       sync.Mutex
+	charts   map[string]*chart.Chart
+	inFlight map[string]*chartFetch
+}
+
+// chartFetch tracks a fetch in progress, so concurrent cache misses for the
+// same chart wait on a single download instead of racing each other.
+type chartFetch struct {
+	done  chan struct{}
+	chart *chart.Chart
+	err   error
+}
+
+func newChartCache() *chartCache {
+	return &chartCache{
+		charts:   map[string]*chart.Chart{},
+		inFlight: map[string]*chartFetch{},
+	}
+}
+
+func (c *chartCache) Load(ctx context.Context, source *v1.HelmChartSource) (*chart.Chart, error) {
+	key := chartDigest(source)
+
+	c.mu.Lock()
+	if cached, ok := c.charts[key]; ok {
+		c.mu.Unlock()
+		return cached, nil
+	}
+	if fetch, ok := c.inFlight[key]; ok {
+		c.mu.Unlock()
+		<-fetch.done
+		return fetch.chart, fetch.err
+	}
+	fetch := &chartFetch{done: make(chan struct{})}
+	c.inFlight[key] = fetch
+	c.mu.Unlock()
+
+	fetch.chart, fetch.err = fetchChart(ctx, source)
+	close(fetch.done)
+
+	c.mu.Lock()
+	delete(c.inFlight, key)
+	if fetch.err == nil {
+		c.charts[key] = fetch.chart
+	}
+	c.mu.Unlock()
+
+	return fetch.chart, fetch.err
+}
+
+func chartDigest(source *v1.HelmChartSource) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s|%s|%s|%s", source.RepoURL, source.ChartName, source.Version, source.OCIRef)
+	return fmt.Sprintf("%x", h.Sum(nil))
+}
+
+// fetchChart downloads source via Helm's chart downloader (which
+// transparently handles both classic repo URLs and OCI refs) and loads the
+// resulting archive. The download is written to a private temp directory
+// that is removed once the chart is loaded, so concurrent fetches (and a
+// read-only working directory) are both safe.
+func fetchChart(_ context.Context, source *v1.HelmChartSource) (*chart.Chart, error) {
+	getters := getter.All(cli.New())
+	ref, err := resolveChartRef(source, getters)
+	if err != nil {
+		return nil, err
+	}
+
+	destDir, err := os.MkdirTemp("", "service-mesh-hub-chart-")
+	if err != nil {
+		return nil, fmt.Errorf("creating chart download dir for %s: %w", ref, err)
+	}
+	defer os.RemoveAll(destDir)
+
+	dl := downloader.ChartDownloader{Getters: getters}
+	chartPath, _, err := dl.DownloadTo(ref, source.Version, destDir)
+	if err != nil {
+		return nil, fmt.Errorf("fetching chart %s (version %s): %w", ref, source.Version, err)
+	}
+
+	loaded, err := loader.Load(chartPath)
+	if err != nil {
+		return nil, fmt.Errorf("loading fetched chart %s: %w", ref, err)
+	}
+	return loaded, nil
+}
+
+// resolveChartRef turns a HelmChartSource into the reference Helm's
+// downloader expects: an OCI ref is used as-is, and a classic repo
+// URL+chart name is resolved against that repo's index to a concrete chart
+// archive URL (the downloader itself has no repo index to consult, so it
+// cannot do this resolution on its own).
+func resolveChartRef(source *v1.HelmChartSource, getters getter.Providers) (string, error) {
+	switch {
+	case source.OCIRef != "":
+		return source.OCIRef, nil
+	case source.RepoURL != "":
+		chartURL, err := repo.FindChartInRepoURL(source.RepoURL, source.ChartName, source.Version, "", "", "", getters)
+		if err != nil {
+			return "", fmt.Errorf("resolving chart %q in repo %s: %w", source.ChartName, source.RepoURL, err)
+		}
+		return chartURL, nil
+	case source.ChartName != "":
+		return source.ChartName, nil
+	default:
+		return "", fmt.Errorf("helm chart source has neither an OCIRef nor a RepoURL+ChartName")
+	}
+}
+
+// renderHelmChart renders chart with the given values and returns its
+// resources as unstructured objects.
+func renderHelmChart(chart *chart.Chart, values map[string]interface{}, releaseName, namespace string) ([]*unstructured.Unstructured, error) {
+	renderValues, err := chartutil.ToRenderValues(chart, values, chartutil.ReleaseOptions{
+		Name:      releaseName,
+		Namespace: namespace,
+	}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("computing render values for chart %s: %w", chart.Name(), err)
+	}
+
+	rendered, err := engine.Render(chart, renderValues)
+	if err != nil {
+		return nil, fmt.Errorf("rendering chart %s: %w", chart.Name(), err)
+	}
+
+	var resources []*unstructured.Unstructured
+	for name, manifest := range rendered {
+		if isEmptyManifestOrHelper(name, manifest) {
+			continue
+		}
+		parsed, err := parseManifest([]byte(manifest))
+		if err != nil {
+			return nil, fmt.Errorf("parsing chart %s manifest %s: %w", chart.Name(), name, err)
+		}
+		resources = append(resources, parsed...)
+	}
+	return resources, nil
+}
+
+func isEmptyManifestOrHelper(name, manifest string) bool {
+	if len(manifest) == 0 {
+		return true
+	}
+	if strings.HasSuffix(name, "NOTES.txt") {
+		return true
+	}
+	base := name
+	for i := len(name) - 1; i >= 0; i-- {
+		if name[i] == '/' {
+			base = name[i+1:]
+			break
+		}
+	}
+	return len(base) > 0 && base[0] == '_'
+}
+
+// chartDefaultValues returns the chart's own values.yaml, to be merged at
+// the "chart defaults" precedence level.
+func chartDefaultValues(chart *chart.Chart) map[string]interface{} {
+	if chart == nil || chart.Values == nil {
+		return map[string]interface{}{}
+	}
+	return chart.Values
+}
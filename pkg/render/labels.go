@@ -0,0 +1,128 @@
+package render
+
+import (
+	"fmt"
+	"strings"
+
+	v1 "github.com/solo-io/service-mesh-hub/api/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// podTemplateSpecPaths are the resource kinds whose nested pod template
+// also needs to receive the merged labels/annotations so they propagate to
+// the workloads those controllers create.
+var podTemplateSpecPaths = map[string][]string{
+	"Deployment":  {"spec", "template"},
+	"StatefulSet": {"spec", "template"},
+	"DaemonSet":   {"spec", "template"},
+	"Job":         {"spec", "template"},
+}
+
+// mergeLabelsAndAnnotations computes the effective labels/annotations for a
+// rendered install, in ascending precedence: spec-defined < flavor-defined
+// < user-supplied.
+func mergeLabelsAndAnnotations(version *v1.VersionedApplicationSpec, flavor *v1.Flavor, inputs ValuesInputs) (map[string]string, map[string]string, error) {
+	labels := map[string]string{}
+	annotations := map[string]string{}
+
+	mergeMap(labels, version.Labels)
+	mergeMap(annotations, version.Annotations)
+	if flavor != nil {
+		mergeMap(labels, flavor.Labels)
+		mergeMap(annotations, flavor.Annotations)
+	}
+	mergeMap(labels, inputs.Labels)
+	mergeMap(annotations, inputs.Annotations)
+
+	if err := validateLabels(labels); err != nil {
+		return nil, nil, err
+	}
+	if err := validateAnnotations(annotations); err != nil {
+		return nil, nil, err
+	}
+
+	return labels, annotations, nil
+}
+
+func mergeMap(into, from map[string]string) {
+	for k, v := range from {
+		into[k] = v
+	}
+}
+
+func validateLabels(labels map[string]string) error {
+	for key, value := range labels {
+		if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+			return fmt.Errorf("invalid label key %q: %s", key, strings.Join(errs, "; "))
+		}
+		if value == "" {
+			return fmt.Errorf("label %q must have a non-empty value", key)
+		}
+		if errs := validation.IsValidLabelValue(value); len(errs) > 0 {
+			return fmt.Errorf("invalid label value %q for key %q: %s", value, key, strings.Join(errs, "; "))
+		}
+	}
+	return nil
+}
+
+func validateAnnotations(annotations map[string]string) error {
+	for key, value := range annotations {
+		if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+			return fmt.Errorf("invalid annotation key %q: %s", key, strings.Join(errs, "; "))
+		}
+		if value == "" {
+			return fmt.Errorf("annotation %q must have a non-empty value", key)
+		}
+	}
+	return nil
+}
+
+// applyLabelsAndAnnotations sets labels/annotations on obj's own metadata,
+// and on the metadata of its nested pod template spec, if it has one.
+func applyLabelsAndAnnotations(obj *unstructured.Unstructured, labels, annotations map[string]string) error {
+	if err := applyToMetadata(obj.Object, labels, annotations); err != nil {
+		return err
+	}
+
+	templatePath, ok := podTemplateSpecPaths[obj.GetKind()]
+	if !ok {
+		return nil
+	}
+	template, found, err := unstructured.NestedMap(obj.Object, templatePath...)
+	if err != nil || !found {
+		return err
+	}
+	if err := applyToMetadata(template, labels, annotations); err != nil {
+		return err
+	}
+	return unstructured.SetNestedMap(obj.Object, template, templatePath...)
+}
+
+func applyToMetadata(object map[string]interface{}, labels, annotations map[string]string) error {
+	if len(labels) > 0 {
+		existing, _, err := unstructured.NestedStringMap(object, "metadata", "labels")
+		if err != nil {
+			return err
+		}
+		merged := map[string]string{}
+		mergeMap(merged, existing)
+		mergeMap(merged, labels)
+		if err := unstructured.SetNestedStringMap(object, merged, "metadata", "labels"); err != nil {
+			return err
+		}
+	}
+	if len(annotations) > 0 {
+		existing, _, err := unstructured.NestedStringMap(object, "metadata", "annotations")
+		if err != nil {
+			return err
+		}
+		merged := map[string]string{}
+		mergeMap(merged, existing)
+		mergeMap(merged, annotations)
+		if err := unstructured.SetNestedStringMap(object, merged, "metadata", "annotations"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
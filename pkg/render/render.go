@@ -0,0 +1,336 @@
+package render
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"text/template"
+
+	v1 "github.com/solo-io/service-mesh-hub/api/v1"
+	"github.com/solo-io/service-mesh-hub/pkg/render/util"
+	"github.com/solo-io/service-mesh-hub/pkg/secrets"
+	"github.com/solo-io/solo-kit/pkg/api/v1/resources/core"
+	"helm.sh/helm/v3/pkg/chart"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+// defaultChartLoader is shared across installs that don't provide their own
+// ChartLoader, so charts are only fetched once per process.
+var defaultChartLoader = newChartCache()
+
+// LayerInput selects one option for one layer of a VersionedApplicationSpec.
+type LayerInput struct {
+	LayerId  string
+	OptionId string
+}
+
+// ValuesInputs carries everything needed to render the resources for a
+// single application install.
+type ValuesInputs struct {
+	Name             string
+	Flavor           *v1.Flavor
+	Layers           []LayerInput
+	InstallNamespace string
+	MeshRef          core.ResourceRef
+
+	// SpecDefinedValues, UserDefinedValues and Params are three of the
+	// value sources merged by computeValues; see its doc comment for the
+	// full precedence order.
+	SpecDefinedValues string
+	UserDefinedValues string
+	Params            map[string]string
+
+	// ParamValues holds typed parameter values (as opposed to the
+	// already-stringified Params above) that must be resolved - including
+	// secret-backed ones - before they can be merged into the values used
+	// to render templates.
+	ParamValues map[string]*v1.ParameterValue
+
+	// SecretResolver resolves ParamValues of type SecretValue. It may be
+	// nil if the install has no secret-typed parameters; resolving a
+	// secret-typed parameter with a nil resolver is an error.
+	SecretResolver secrets.SecretResolver
+
+	// Labels and Annotations are applied to every rendered resource's
+	// metadata, including the pod template of any Deployment, StatefulSet,
+	// DaemonSet or Job, at the highest precedence: spec-defined <
+	// flavor-defined < these.
+	Labels      map[string]string
+	Annotations map[string]string
+
+	// EventSink, if set, receives CloudEvents describing the render
+	// lifecycle. Defaults to NoOpEventSink.
+	EventSink EventSink
+
+	// ChartLoader fetches the chart for a Helm-sourced Flavor. Defaults to
+	// a process-wide chartCache; tests should inject a fake.
+	ChartLoader ChartLoader
+}
+
+func (inputs ValuesInputs) chartLoader() ChartLoader {
+	if inputs.ChartLoader != nil {
+		return inputs.ChartLoader
+	}
+	return defaultChartLoader
+}
+
+// ComputeResourcesForApplication renders every resource produced by the
+// selected flavor and layers of version, using inputs to resolve template
+// values.
+func ComputeResourcesForApplication(ctx context.Context, inputs ValuesInputs, version *v1.VersionedApplicationSpec) (*RenderResult, error) {
+	sink := eventSink(inputs)
+	emitEvent(ctx, sink, newRenderEvent(ctx, eventTypeRenderStarted, inputs, version.Version, renderEventData{}, nil))
+
+	result, err := computeResourcesForApplication(ctx, inputs, version)
+	if err != nil {
+		resourceCount := 0
+		if result != nil {
+			resourceCount = len(result.Resources)
+		}
+		emitEvent(ctx, sink, newRenderEvent(ctx, eventTypeRenderFailed, inputs, version.Version, renderEventData{ResourceCount: resourceCount}, err))
+		return nil, err
+	}
+
+	emitEvent(ctx, sink, newRenderEvent(ctx, eventTypeRenderCompleted, inputs, version.Version, renderEventData{ResourceCount: len(result.Resources)}, nil))
+	return result, nil
+}
+
+func computeResourcesForApplication(ctx context.Context, inputs ValuesInputs, version *v1.VersionedApplicationSpec) (*RenderResult, error) {
+	selectedLayers, err := resolveSelectedLayers(version, inputs)
+	if err != nil {
+		return nil, err
+	}
+
+	helmSource := flavorHelmSource(inputs.Flavor)
+	var helmChart *chart.Chart
+	if helmSource != nil {
+		helmChart, err = inputs.chartLoader().Load(ctx, helmSource)
+		if err != nil {
+			return nil, fmt.Errorf("loading chart for flavor %q: %w", inputs.Flavor.Name, err)
+		}
+	}
+
+	values, err := computeValues(ctx, inputs, helmChart, selectedLayers)
+	if err != nil {
+		return nil, err
+	}
+
+	labels, annotations, err := mergeLabelsAndAnnotations(version, inputs.Flavor, inputs)
+	if err != nil {
+		return nil, err
+	}
+
+	var tagged []taggedResource
+	for _, layer := range selectedLayers {
+		if layer.option.ManifestTemplate == "" {
+			continue
+		}
+		rendered, err := renderManifestTemplate(layer.input, layer.option, values)
+		if err != nil {
+			return nil, err
+		}
+		origin := manifestTemplateOrigin(layer.input)
+		for _, resource := range rendered {
+			tagged = append(tagged, taggedResource{resource: resource, origin: origin})
+		}
+	}
+
+	if helmChart != nil {
+		rendered, err := renderHelmChart(helmChart, values, inputs.Name, inputs.InstallNamespace)
+		if err != nil {
+			return nil, fmt.Errorf("rendering chart for flavor %q: %w", inputs.Flavor.Name, err)
+		}
+		origin := helmChartOrigin(helmChart.Name())
+		for _, resource := range rendered {
+			tagged = append(tagged, taggedResource{resource: resource, origin: origin})
+		}
+	}
+
+	for _, t := range tagged {
+		if err := applyLabelsAndAnnotations(t.resource, labels, annotations); err != nil {
+			return nil, fmt.Errorf("applying labels/annotations to %s %s: %w", t.resource.GetKind(), t.resource.GetName(), err)
+		}
+	}
+
+	sortTaggedResourcesDeterministically(tagged)
+	origins := tagAndIdentify(tagged)
+
+	resources := make([]*unstructured.Unstructured, 0, len(tagged))
+	for _, t := range tagged {
+		resources = append(resources, t.resource)
+	}
+
+	idsByObject := make(map[*unstructured.Unstructured]string, len(tagged))
+	for _, t := range tagged {
+		idsByObject[t.resource] = t.resource.GetAnnotations()[ResourceIDAnnotation]
+	}
+	edges := computeDependencyEdges(resources, idsByObject)
+
+	return &RenderResult{
+		Resources: resources,
+		Origins:   origins,
+		Edges:     edges,
+	}, nil
+}
+
+// selectedLayer pairs a LayerInput with the LayerOption it resolved to.
+type selectedLayer struct {
+	input  LayerInput
+	option *v1.LayerOption
+}
+
+// resolveSelectedLayers looks up the option for every layer selected
+// explicitly via inputs.Layers and implicitly via inputs.Flavor.Layers.
+func resolveSelectedLayers(version *v1.VersionedApplicationSpec, inputs ValuesInputs) ([]selectedLayer, error) {
+	var layerInputs []LayerInput
+	layerInputs = append(layerInputs, inputs.Layers...)
+	if inputs.Flavor != nil {
+		for _, sel := range inputs.Flavor.Layers {
+			layerInputs = append(layerInputs, LayerInput{LayerId: sel.LayerId, OptionId: sel.OptionId})
+		}
+	}
+
+	selected := make([]selectedLayer, 0, len(layerInputs))
+	for _, layerInput := range layerInputs {
+		layer, ok := version.Layers[layerInput.LayerId]
+		if !ok {
+			return nil, fmt.Errorf("layer %q not found on version %s", layerInput.LayerId, version.Version)
+		}
+		option, ok := layer.Options[layerInput.OptionId]
+		if !ok {
+			return nil, fmt.Errorf("option %q not found on layer %q", layerInput.OptionId, layerInput.LayerId)
+		}
+		selected = append(selected, selectedLayer{input: layerInput, option: option})
+	}
+	return selected, nil
+}
+
+func flavorHelmSource(flavor *v1.Flavor) *v1.HelmChartSource {
+	if flavor == nil || flavor.Source == nil {
+		return nil
+	}
+	return flavor.Source.Helm
+}
+
+// computeValues merges the value sources in ascending precedence -
+// SpecDefinedValues < Flavor.ValuesYaml < chart defaults < selected layer
+// option values < UserDefinedValues < Params < ParamValues - and resolves
+// any typed parameters (including secret-backed ones) into the result.
+func computeValues(ctx context.Context, inputs ValuesInputs, helmChart *chart.Chart, selectedLayers []selectedLayer) (map[string]interface{}, error) {
+	values := map[string]interface{}{}
+
+	if err := mergeYamlValues(values, inputs.SpecDefinedValues); err != nil {
+		return nil, err
+	}
+	if err := mergeYamlValues(values, flavorValues(inputs.Flavor)); err != nil {
+		return nil, err
+	}
+
+	if helmChart != nil {
+		mergeMapValues(values, chartDefaultValues(helmChart))
+	}
+
+	for _, layer := range selectedLayers {
+		if err := mergeYamlValues(values, layer.option.ValuesYaml); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := mergeYamlValues(values, inputs.UserDefinedValues); err != nil {
+		return nil, err
+	}
+
+	for key, value := range inputs.Params {
+		values[key] = value
+	}
+
+	for key, paramValue := range inputs.ParamValues {
+		resolved, err := util.ResolveParamValue(ctx, inputs.SecretResolver, paramValue)
+		if err != nil {
+			return nil, fmt.Errorf("resolving parameter %q: %w", key, err)
+		}
+		values[key] = resolved
+	}
+
+	return values, nil
+}
+
+func flavorValues(flavor *v1.Flavor) string {
+	if flavor == nil {
+		return ""
+	}
+	return flavor.ValuesYaml
+}
+
+func mergeMapValues(into, overlay map[string]interface{}) {
+	for key, value := range overlay {
+		into[key] = value
+	}
+}
+
+// sortTaggedResourcesDeterministically orders resources by kind, namespace
+// and name so the merged manifest stream from layers and a Helm chart has a
+// stable, reproducible order regardless of render order.
+func sortTaggedResourcesDeterministically(tagged []taggedResource) {
+	sort.SliceStable(tagged, func(i, j int) bool {
+		a, b := tagged[i].resource, tagged[j].resource
+		if a.GetKind() != b.GetKind() {
+			return a.GetKind() < b.GetKind()
+		}
+		if a.GetNamespace() != b.GetNamespace() {
+			return a.GetNamespace() < b.GetNamespace()
+		}
+		return a.GetName() < b.GetName()
+	})
+}
+
+func mergeYamlValues(into map[string]interface{}, raw string) error {
+	if raw == "" {
+		return nil
+	}
+	var overlay map[string]interface{}
+	if err := yaml.Unmarshal([]byte(raw), &overlay); err != nil {
+		return fmt.Errorf("parsing values: %w", err)
+	}
+	for key, value := range overlay {
+		into[key] = value
+	}
+	return nil
+}
+
+func renderManifestTemplate(input LayerInput, option *v1.LayerOption, values map[string]interface{}) ([]*unstructured.Unstructured, error) {
+	tmpl, err := template.New(input.LayerId + "/" + input.OptionId).Parse(option.ManifestTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("parsing layer %q option %q: %w", input.LayerId, input.OptionId, err)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, values); err != nil {
+		return nil, fmt.Errorf("rendering layer %q option %q: %w", input.LayerId, input.OptionId, err)
+	}
+
+	return parseManifest(buf.Bytes())
+}
+
+func parseManifest(manifest []byte) ([]*unstructured.Unstructured, error) {
+	var resources []*unstructured.Unstructured
+	for _, doc := range splitYamlDocs(manifest) {
+		if len(bytes.TrimSpace(doc)) == 0 {
+			continue
+		}
+		obj := &unstructured.Unstructured{}
+		if err := yaml.Unmarshal(doc, &obj.Object); err != nil {
+			return nil, fmt.Errorf("parsing rendered manifest: %w", err)
+		}
+		if len(obj.Object) == 0 {
+			continue
+		}
+		resources = append(resources, obj)
+	}
+	return resources, nil
+}
+
+func splitYamlDocs(manifest []byte) [][]byte {
+	return bytes.Split(manifest, []byte("\n---\n"))
+}
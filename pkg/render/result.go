@@ -0,0 +1,82 @@
+package render
+
+import (
+	"crypto/sha256"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const (
+	// ResourceIDAnnotation records a resource's stable ID, so re-renders
+	// across versions can be diffed and individual resources referenced by
+	// upgrade jobs (e.g. gateway-conversion).
+	ResourceIDAnnotation = "extensions.solo.io/resource-id"
+	// OriginLayerAnnotation records the layer/option (or Helm chart) that
+	// produced a resource.
+	OriginLayerAnnotation = "extensions.solo.io/origin-layer"
+)
+
+// RenderResult is everything ComputeResourcesForApplication produces for a
+// single install: the resources themselves, and enough metadata about them
+// to diff across renders and reason about their relationships.
+type RenderResult struct {
+	Resources []*unstructured.Unstructured
+
+	// Origins maps a resource's stable ID to the layer/option or Helm
+	// chart that produced it.
+	Origins map[string]string
+
+	// Edges describes the dependency graph between rendered resources,
+	// e.g. a Deployment depending on the ServiceAccount it runs as.
+	Edges []DependencyEdge
+}
+
+// DependencyEdge is a directed "depends on" relationship between two
+// rendered resources, identified by their stable resource IDs.
+type DependencyEdge struct {
+	From string
+	To   string
+}
+
+type taggedResource struct {
+	resource *unstructured.Unstructured
+	origin   string
+}
+
+// resourceID returns a stable ID for a resource produced by origin: a hash
+// of its GVK, namespace, name and origin. Two renders of the same spec
+// produce identical IDs; the ID changes only if the resource's identity or
+// origin changes.
+func resourceID(obj *unstructured.Unstructured, origin string) string {
+	gvk := obj.GroupVersionKind()
+	h := sha256.New()
+	fmt.Fprintf(h, "%s|%s|%s|%s", gvk.String(), obj.GetNamespace(), obj.GetName(), origin)
+	return fmt.Sprintf("%x", h.Sum(nil))[:16]
+}
+
+func manifestTemplateOrigin(input LayerInput) string {
+	return input.LayerId + "/" + input.OptionId
+}
+
+func helmChartOrigin(chartName string) string {
+	return "helm/" + chartName
+}
+
+// tagAndIdentify computes each resource's stable ID and records it (and its
+// origin) as annotations, returning the Origins map for the RenderResult.
+func tagAndIdentify(tagged []taggedResource) map[string]string {
+	origins := make(map[string]string, len(tagged))
+	for _, t := range tagged {
+		id := resourceID(t.resource, t.origin)
+		annotations := t.resource.GetAnnotations()
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		annotations[ResourceIDAnnotation] = id
+		annotations[OriginLayerAnnotation] = t.origin
+		t.resource.SetAnnotations(annotations)
+		origins[id] = t.origin
+	}
+	return origins
+}
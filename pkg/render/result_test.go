@@ -0,0 +1,148 @@
+package render_test
+
+import (
+	"context"
+	"testing"
+
+	v1 "github.com/solo-io/service-mesh-hub/api/v1"
+	"github.com/solo-io/service-mesh-hub/pkg/render"
+)
+
+const serviceAccountManifest = `apiVersion: v1
+kind: ServiceAccount
+metadata:
+  name: gloo
+  namespace: gloo-system
+`
+
+const deploymentManifest = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: gloo
+  namespace: gloo-system
+spec:
+  template:
+    metadata:
+      labels:
+        app: gloo
+    spec:
+      serviceAccountName: gloo
+`
+
+func versionWithServiceAccountAndDeployment() *v1.VersionedApplicationSpec {
+	return &v1.VersionedApplicationSpec{
+		Version: "1.0.0",
+		Layers: map[string]*v1.Layer{
+			"service-account": {
+				Options: map[string]*v1.LayerOption{
+					"create": {ManifestTemplate: serviceAccountManifest},
+				},
+			},
+			"deployment": {
+				Options: map[string]*v1.LayerOption{
+					"create": {ManifestTemplate: deploymentManifest},
+				},
+			},
+		},
+	}
+}
+
+func TestComputeResourcesForApplication_StableIDsAcrossLayerReordering(t *testing.T) {
+	version := versionWithServiceAccountAndDeployment()
+
+	forward := render.ValuesInputs{
+		Name: "gloo",
+		Layers: []render.LayerInput{
+			{LayerId: "service-account", OptionId: "create"},
+			{LayerId: "deployment", OptionId: "create"},
+		},
+	}
+	reversed := render.ValuesInputs{
+		Name: "gloo",
+		Layers: []render.LayerInput{
+			{LayerId: "deployment", OptionId: "create"},
+			{LayerId: "service-account", OptionId: "create"},
+		},
+	}
+
+	forwardResult, err := render.ComputeResourcesForApplication(context.TODO(), forward, version)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	reversedResult, err := render.ComputeResourcesForApplication(context.TODO(), reversed, version)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	forwardIDs := resourceIDsByName(forwardResult)
+	reversedIDs := resourceIDsByName(reversedResult)
+	for name, id := range forwardIDs {
+		if reversedIDs[name] != id {
+			t.Fatalf("resource %q got a different ID after reordering layers: %s vs %s", name, id, reversedIDs[name])
+		}
+	}
+}
+
+func TestComputeResourcesForApplication_IDChangesWithOrigin(t *testing.T) {
+	version := versionWithServiceAccountAndDeployment()
+	version.Layers["service-account"].Options["replace"] = &v1.LayerOption{ManifestTemplate: serviceAccountManifest}
+
+	createInputs := render.ValuesInputs{
+		Name:   "gloo",
+		Layers: []render.LayerInput{{LayerId: "service-account", OptionId: "create"}},
+	}
+	replaceInputs := render.ValuesInputs{
+		Name:   "gloo",
+		Layers: []render.LayerInput{{LayerId: "service-account", OptionId: "replace"}},
+	}
+
+	createResult, err := render.ComputeResourcesForApplication(context.TODO(), createInputs, version)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	replaceResult, err := render.ComputeResourcesForApplication(context.TODO(), replaceInputs, version)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	createID := createResult.Resources[0].GetAnnotations()[render.ResourceIDAnnotation]
+	replaceID := replaceResult.Resources[0].GetAnnotations()[render.ResourceIDAnnotation]
+	if createID == replaceID {
+		t.Fatalf("expected different IDs for resources produced by different layer options, got %s for both", createID)
+	}
+}
+
+func TestComputeResourcesForApplication_DeploymentDependsOnServiceAccount(t *testing.T) {
+	version := versionWithServiceAccountAndDeployment()
+	inputs := render.ValuesInputs{
+		Name: "gloo",
+		Layers: []render.LayerInput{
+			{LayerId: "service-account", OptionId: "create"},
+			{LayerId: "deployment", OptionId: "create"},
+		},
+	}
+
+	result, err := render.ComputeResourcesForApplication(context.TODO(), inputs, version)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ids := resourceIDsByName(result)
+	deploymentID := ids["gloo-Deployment"]
+	serviceAccountID := ids["gloo-ServiceAccount"]
+
+	for _, edge := range result.Edges {
+		if edge.From == deploymentID && edge.To == serviceAccountID {
+			return
+		}
+	}
+	t.Fatalf("expected a dependency edge from the Deployment to the ServiceAccount, got %+v", result.Edges)
+}
+
+func resourceIDsByName(result *render.RenderResult) map[string]string {
+	ids := map[string]string{}
+	for _, resource := range result.Resources {
+		ids[resource.GetName()+"-"+resource.GetKind()] = resource.GetAnnotations()[render.ResourceIDAnnotation]
+	}
+	return ids
+}
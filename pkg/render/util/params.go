@@ -1,11 +1,17 @@
 package util
 
 import (
+	"context"
+	"fmt"
 	"strconv"
 
 	v1 "github.com/solo-io/service-mesh-hub/api/v1"
+	"github.com/solo-io/service-mesh-hub/pkg/secrets"
 )
 
+// ParamValueToString renders value to its string form. Secret-typed values
+// cannot be resolved without a SecretResolver and a context, so callers that
+// may encounter them should use ResolveParamValue instead.
 func ParamValueToString(value *v1.ParameterValue) string {
 	switch t := value.GetType().(type) {
 	case *v1.ParameterValue_BooleanValue:
@@ -20,11 +26,30 @@ func ParamValueToString(value *v1.ParameterValue) string {
 	case *v1.ParameterValue_IntValue:
 		return strconv.Itoa(int(t.IntValue))
 	case *v1.ParameterValue_SecretValue:
-		// TODO not yet supported
 		return ""
 	case *v1.ParameterValue_StringValue:
 		return t.StringValue
 	}
 
 	return ""
+}
+
+// ResolveParamValue renders value to its string form, resolving
+// ParameterValue_SecretValue through resolver. It returns an error if value
+// is a secret reference and resolver is nil, or if resolution fails.
+func ResolveParamValue(ctx context.Context, resolver secrets.SecretResolver, value *v1.ParameterValue) (string, error) {
+	secretValue, ok := value.GetType().(*v1.ParameterValue_SecretValue)
+	if !ok {
+		return ParamValueToString(value), nil
+	}
+
+	if resolver == nil {
+		return "", secrets.ErrResolverNotConfigured
+	}
+
+	resolved, err := resolver.Resolve(ctx, secretValue.SecretValue)
+	if err != nil {
+		return "", fmt.Errorf("resolving secret value: %w", err)
+	}
+	return resolved, nil
 }
\ No newline at end of file
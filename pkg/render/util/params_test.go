@@ -0,0 +1,60 @@
+package util_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	v1 "github.com/solo-io/service-mesh-hub/api/v1"
+	"github.com/solo-io/service-mesh-hub/pkg/render/util"
+	"github.com/solo-io/service-mesh-hub/pkg/secrets"
+)
+
+type fakeSecretResolver struct {
+	value string
+	err   error
+}
+
+func (f *fakeSecretResolver) Resolve(_ context.Context, _ *v1.SecretValue) (string, error) {
+	return f.value, f.err
+}
+
+func TestResolveParamValue_NonSecret(t *testing.T) {
+	value := &v1.ParameterValue{Type: &v1.ParameterValue_StringValue{StringValue: "hello"}}
+
+	got, err := util.ResolveParamValue(context.TODO(), nil, value)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != "hello" {
+		t.Fatalf("expected %q, got %q", "hello", got)
+	}
+}
+
+func TestResolveParamValue_SecretNoResolver(t *testing.T) {
+	value := &v1.ParameterValue{Type: &v1.ParameterValue_SecretValue{SecretValue: &v1.SecretValue{}}}
+
+	_, err := util.ResolveParamValue(context.TODO(), nil, value)
+	if !errors.Is(err, secrets.ErrResolverNotConfigured) {
+		t.Fatalf("expected ErrResolverNotConfigured, got %v", err)
+	}
+}
+
+func TestResolveParamValue_SecretResolved(t *testing.T) {
+	value := &v1.ParameterValue{Type: &v1.ParameterValue_SecretValue{SecretValue: &v1.SecretValue{
+		Ref: &v1.SecretValue_Kubernetes{Kubernetes: &v1.KubernetesSecretRef{
+			Namespace: "gloo-system",
+			Name:      "my-secret",
+			Key:       "token",
+		}},
+	}}}
+
+	resolver := &fakeSecretResolver{value: "s3cr3t"}
+	got, err := util.ResolveParamValue(context.TODO(), resolver, value)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != "s3cr3t" {
+		t.Fatalf("expected %q, got %q", "s3cr3t", got)
+	}
+}
@@ -0,0 +1,50 @@
+package secrets
+
+import (
+	"context"
+	"fmt"
+
+	v1 "github.com/solo-io/service-mesh-hub/api/v1"
+	kubev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// KubernetesSecretResolver resolves SecretValues backed by a Kubernetes
+// Secret's data key.
+type KubernetesSecretResolver struct {
+	client kubernetes.Interface
+}
+
+func NewKubernetesSecretResolver(client kubernetes.Interface) *KubernetesSecretResolver {
+	return &KubernetesSecretResolver{client: client}
+}
+
+func (r *KubernetesSecretResolver) Resolve(ctx context.Context, ref *v1.SecretValue) (string, error) {
+	kube, ok := ref.GetRef().(*v1.SecretValue_Kubernetes)
+	if !ok {
+		return "", fmt.Errorf("kubernetes secret resolver cannot resolve ref of type %T", ref.GetRef())
+	}
+	secretRef := kube.Kubernetes
+
+	secret, err := r.client.CoreV1().Secrets(secretRef.Namespace).Get(ctx, secretRef.Name, metav1.GetOptions{})
+	if err != nil {
+		return "", fmt.Errorf("fetching secret %s/%s: %w", secretRef.Namespace, secretRef.Name, err)
+	}
+
+	value, ok := secretValueData(secret, secretRef.Key)
+	if !ok {
+		return "", fmt.Errorf("secret %s/%s has no key %q", secretRef.Namespace, secretRef.Name, secretRef.Key)
+	}
+	return value, nil
+}
+
+func secretValueData(secret *kubev1.Secret, key string) (string, bool) {
+	if raw, ok := secret.Data[key]; ok {
+		return string(raw), true
+	}
+	if raw, ok := secret.StringData[key]; ok {
+		return raw, true
+	}
+	return "", false
+}
@@ -0,0 +1,21 @@
+// Package secrets resolves SecretValue references from application
+// parameters against a pluggable secret backend.
+package secrets
+
+import (
+	"context"
+	"errors"
+
+	v1 "github.com/solo-io/service-mesh-hub/api/v1"
+)
+
+// ErrResolverNotConfigured is returned when a parameter references a secret
+// but no SecretResolver was provided.
+var ErrResolverNotConfigured = errors.New("secret value provided but no secret resolver is configured")
+
+// SecretResolver resolves a SecretValue reference to its plaintext value.
+type SecretResolver interface {
+	// Resolve returns the plaintext value referenced by ref, or an error if
+	// the secret does not exist or cannot be read.
+	Resolve(ctx context.Context, ref *v1.SecretValue) (string, error)
+}
@@ -0,0 +1,56 @@
+package secrets
+
+import (
+	"context"
+	"fmt"
+
+	vaultapi "github.com/hashicorp/vault/api"
+	v1 "github.com/solo-io/service-mesh-hub/api/v1"
+)
+
+// VaultSecretResolver resolves SecretValues backed by a field in a
+// HashiCorp Vault secret. Works against both KV v1 and KV v2 mounts.
+type VaultSecretResolver struct {
+	client *vaultapi.Client
+}
+
+func NewVaultSecretResolver(client *vaultapi.Client) *VaultSecretResolver {
+	return &VaultSecretResolver{client: client}
+}
+
+func (r *VaultSecretResolver) Resolve(ctx context.Context, ref *v1.SecretValue) (string, error) {
+	vaultRef, ok := ref.GetRef().(*v1.SecretValue_Vault)
+	if !ok {
+		return "", fmt.Errorf("vault secret resolver cannot resolve ref of type %T", ref.GetRef())
+	}
+
+	secret, err := r.client.Logical().ReadWithContext(ctx, vaultRef.Vault.Path)
+	if err != nil {
+		return "", fmt.Errorf("reading vault secret %s: %w", vaultRef.Vault.Path, err)
+	}
+	if secret == nil || secret.Data == nil {
+		return "", fmt.Errorf("vault secret %s not found", vaultRef.Vault.Path)
+	}
+
+	raw, ok := secretFields(secret.Data)[vaultRef.Vault.Field]
+	if !ok {
+		return "", fmt.Errorf("vault secret %s has no field %q", vaultRef.Vault.Path, vaultRef.Vault.Field)
+	}
+	value, ok := raw.(string)
+	if !ok {
+		return "", fmt.Errorf("vault secret %s field %q is not a string", vaultRef.Vault.Path, vaultRef.Vault.Field)
+	}
+	return value, nil
+}
+
+// secretFields returns the map that actually holds a secret's fields. KV v2
+// mounts nest them under a "data" key alongside metadata (version, created
+// time, ...), while KV v1 mounts return the fields directly; this treats the
+// nested "data" map as the fields when present, falling back to data itself
+// for KV v1.
+func secretFields(data map[string]interface{}) map[string]interface{} {
+	if nested, ok := data["data"].(map[string]interface{}); ok {
+		return nested
+	}
+	return data
+}
@@ -0,0 +1,30 @@
+package secrets
+
+import "testing"
+
+func TestSecretFields_KVv2NestsUnderData(t *testing.T) {
+	data := map[string]interface{}{
+		"data": map[string]interface{}{
+			"password": "hunter2",
+		},
+		"metadata": map[string]interface{}{
+			"version": 3,
+		},
+	}
+
+	fields := secretFields(data)
+	if fields["password"] != "hunter2" {
+		t.Fatalf("expected KV v2 fields to be read from the nested data map, got %v", fields)
+	}
+}
+
+func TestSecretFields_KVv1ReadsDataDirectly(t *testing.T) {
+	data := map[string]interface{}{
+		"password": "hunter2",
+	}
+
+	fields := secretFields(data)
+	if fields["password"] != "hunter2" {
+		t.Fatalf("expected KV v1 fields to be read directly, got %v", fields)
+	}
+}
@@ -0,0 +1,109 @@
+package e2e
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apiextclient "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
+)
+
+const (
+	defaultPollInterval = 2 * time.Second
+	defaultPollTimeout  = 2 * time.Minute
+)
+
+// AssertDeploymentReady polls until the named Deployment has as many ready
+// replicas as it requests, or timeout elapses.
+func (c *TestCluster) AssertDeploymentReady(ctx context.Context, namespace, name string) error {
+	return pollUntil(ctx, func(ctx context.Context) (bool, error) {
+		dep, err := c.Clientset.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return false, nil
+		}
+		want := int32(1)
+		if dep.Spec.Replicas != nil {
+			want = *dep.Spec.Replicas
+		}
+		return dep.Status.ReadyReplicas >= want, nil
+	})
+}
+
+// AssertServiceEndpointsReady polls until the named Service has at least one
+// ready address across all of its Endpoints subsets.
+func (c *TestCluster) AssertServiceEndpointsReady(ctx context.Context, namespace, name string) error {
+	return pollUntil(ctx, func(ctx context.Context) (bool, error) {
+		endpoints, err := c.Clientset.CoreV1().Endpoints(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return false, nil
+		}
+		for _, subset := range endpoints.Subsets {
+			if len(subset.Addresses) > 0 {
+				return true, nil
+			}
+		}
+		return false, nil
+	})
+}
+
+// AssertJobCompleted polls until the named Job reports at least one
+// successful completion.
+func (c *TestCluster) AssertJobCompleted(ctx context.Context, namespace, name string) error {
+	return pollUntil(ctx, func(ctx context.Context) (bool, error) {
+		job, err := c.Clientset.BatchV1().Jobs(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return false, nil
+		}
+		return jobSucceeded(job), nil
+	})
+}
+
+func jobSucceeded(job *batchv1.Job) bool {
+	return job.Status.Succeeded > 0
+}
+
+// AssertCRDEstablished polls until the named CustomResourceDefinition's
+// Established condition is True.
+func (c *TestCluster) AssertCRDEstablished(ctx context.Context, name string) error {
+	apiextClient, err := apiextclient.NewForConfig(c.RestConfig)
+	if err != nil {
+		return fmt.Errorf("building apiextensions client: %w", err)
+	}
+
+	return pollUntil(ctx, func(ctx context.Context) (bool, error) {
+		crd, err := apiextClient.ApiextensionsV1().CustomResourceDefinitions().Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return false, nil
+		}
+		for _, cond := range crd.Status.Conditions {
+			if cond.Type == apiextv1.Established && cond.Status == apiextv1.ConditionTrue {
+				return true, nil
+			}
+		}
+		return false, nil
+	})
+}
+
+func pollUntil(ctx context.Context, check func(context.Context) (bool, error)) error {
+	deadline := time.Now().Add(defaultPollTimeout)
+	for {
+		ok, err := check(ctx)
+		if err != nil {
+			return err
+		}
+		if ok {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("condition not met after %s", defaultPollTimeout)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(defaultPollInterval):
+		}
+	}
+}
@@ -0,0 +1,78 @@
+// Package helper applies rendered manifests to a real cluster and tracks
+// what it created, so tests can clean up after themselves.
+package helper
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+)
+
+// Installation applies a set of rendered resources to a cluster via the
+// dynamic client, and tracks them so they can be torn down together.
+type Installation struct {
+	dynamicClient dynamic.Interface
+	restMapper    meta.RESTMapper
+	namespace     string
+
+	applied []appliedResource
+}
+
+type appliedResource struct {
+	gvr schema.GroupVersionResource
+	ns  string
+	obj *unstructured.Unstructured
+}
+
+// NewInstallation builds an Installation that applies resources into
+// namespace, resolving kinds to resources via mapper.
+func NewInstallation(dynamicClient dynamic.Interface, mapper meta.RESTMapper, namespace string) *Installation {
+	return &Installation{dynamicClient: dynamicClient, restMapper: mapper, namespace: namespace}
+}
+
+// Apply creates every resource in order, setting the Installation's
+// namespace on namespaced resources that don't already set one.
+func (i *Installation) Apply(ctx context.Context, resources []*unstructured.Unstructured) error {
+	for _, obj := range resources {
+		gvk := obj.GroupVersionKind()
+		mapping, err := i.restMapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+		if err != nil {
+			return fmt.Errorf("mapping %s to a resource: %w", gvk, err)
+		}
+
+		ns := obj.GetNamespace()
+		client := i.dynamicClient.Resource(mapping.Resource)
+		var resourceClient dynamic.ResourceInterface = client
+		if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+			if ns == "" {
+				ns = i.namespace
+				obj.SetNamespace(ns)
+			}
+			resourceClient = client.Namespace(ns)
+		}
+
+		if _, err := resourceClient.Create(ctx, obj, metav1.CreateOptions{}); err != nil {
+			return fmt.Errorf("creating %s %s/%s: %w", gvk.Kind, ns, obj.GetName(), err)
+		}
+		i.applied = append(i.applied, appliedResource{gvr: mapping.Resource, ns: ns, obj: obj})
+	}
+	return nil
+}
+
+// Teardown deletes every resource Apply created, in reverse order.
+func (i *Installation) Teardown(ctx context.Context) error {
+	var firstErr error
+	for idx := len(i.applied) - 1; idx >= 0; idx-- {
+		applied := i.applied[idx]
+		client := i.dynamicClient.Resource(applied.gvr).Namespace(applied.ns)
+		if err := client.Delete(ctx, applied.obj.GetName(), metav1.DeleteOptions{}); err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("deleting %s %s/%s: %w", applied.gvr.Resource, applied.ns, applied.obj.GetName(), err)
+		}
+	}
+	return firstErr
+}
@@ -0,0 +1,32 @@
+package e2e
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+func restConfigFromKubeconfig(kubeconfig string) (*rest.Config, error) {
+	cfg, err := clientcmd.RESTConfigFromKubeConfig([]byte(kubeconfig))
+	if err != nil {
+		return nil, fmt.Errorf("parsing kind kubeconfig: %w", err)
+	}
+	return cfg, nil
+}
+
+// sanitizeForKindName makes suiteName safe to use as (part of) a kind
+// cluster name, which must be a valid DNS label.
+func sanitizeForKindName(suiteName string) string {
+	lower := strings.ToLower(suiteName)
+	var b strings.Builder
+	for _, r := range lower {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '-' {
+			b.WriteRune(r)
+		} else {
+			b.WriteRune('-')
+		}
+	}
+	return strings.Trim(b.String(), "-")
+}
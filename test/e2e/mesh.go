@@ -0,0 +1,53 @@
+package e2e
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/solo-io/service-mesh-hub/pkg/render"
+	"github.com/solo-io/solo-kit/pkg/api/v1/resources/core"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// istioInjectionLabel is the well-known namespace label that opts a
+// namespace into Istio's automatic sidecar injection webhook.
+const istioInjectionLabel = "istio-injection"
+
+// EnableIstioSidecarInjection labels namespace so pods created in it get an
+// Istio sidecar injected automatically.
+func (c *TestCluster) EnableIstioSidecarInjection(ctx context.Context, namespace string) error {
+	ns, err := c.Clientset.CoreV1().Namespaces().Get(ctx, namespace, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("fetching namespace %s: %w", namespace, err)
+	}
+	if ns.Labels == nil {
+		ns.Labels = map[string]string{}
+	}
+	ns.Labels[istioInjectionLabel] = "enabled"
+
+	_, err = c.Clientset.CoreV1().Namespaces().Update(ctx, ns, metav1.UpdateOptions{})
+	if err != nil {
+		return fmt.Errorf("labeling namespace %s for sidecar injection: %w", namespace, err)
+	}
+	return nil
+}
+
+// TranslateMeshRef rewrites a MeshRef resolved against the render fixtures
+// to point at mesh resources actually installed in this cluster's
+// meshNamespace, so the same ValuesInputs used by the in-process render
+// tests can be replayed against a real install.
+func TranslateMeshRef(ref core.ResourceRef, meshNamespace string) core.ResourceRef {
+	return core.ResourceRef{
+		Name:      ref.Name,
+		Namespace: meshNamespace,
+	}
+}
+
+// ForRealCluster adapts inputs - as built for the in-process render suite -
+// for use against this TestCluster, translating its MeshRef and install
+// namespace.
+func (c *TestCluster) ForRealCluster(inputs render.ValuesInputs, installNamespace string) render.ValuesInputs {
+	inputs.InstallNamespace = installNamespace
+	inputs.MeshRef = TranslateMeshRef(inputs.MeshRef, installNamespace)
+	return inputs
+}
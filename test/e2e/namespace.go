@@ -0,0 +1,30 @@
+package e2e
+
+import (
+	"context"
+	"fmt"
+
+	kubev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CreateIsolatedNamespace creates a namespace with a generated, unique name
+// derived from prefix, so parallel suites never collide. It returns the
+// created namespace's name.
+func (c *TestCluster) CreateIsolatedNamespace(ctx context.Context, prefix string) (string, error) {
+	ns := &kubev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: prefix + "-",
+		},
+	}
+	created, err := c.Clientset.CoreV1().Namespaces().Create(ctx, ns, metav1.CreateOptions{})
+	if err != nil {
+		return "", fmt.Errorf("creating namespace with prefix %q: %w", prefix, err)
+	}
+	return created.Name, nil
+}
+
+// DeleteNamespace removes a namespace created by CreateIsolatedNamespace.
+func (c *TestCluster) DeleteNamespace(ctx context.Context, name string) error {
+	return c.Clientset.CoreV1().Namespaces().Delete(ctx, name, metav1.DeleteOptions{})
+}
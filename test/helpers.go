@@ -0,0 +1,34 @@
+// Package test provides fixtures shared by the render and e2e test suites.
+package test
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	v1 "github.com/solo-io/service-mesh-hub/api/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// LoadApplicationSpec reads and parses the ApplicationSpec at path.
+func LoadApplicationSpec(path string) *v1.ApplicationSpec {
+	raw, err := ioutil.ReadFile(path)
+	if err != nil {
+		panic(fmt.Errorf("reading application spec %s: %w", path, err))
+	}
+
+	spec := &v1.ApplicationSpec{}
+	if err := yaml.Unmarshal(raw, spec); err != nil {
+		panic(fmt.Errorf("parsing application spec %s: %w", path, err))
+	}
+	return spec
+}
+
+// GetFlavor returns the named flavor of version, panicking if it doesn't
+// exist - tests should fail loudly on a fixture typo, not skip silently.
+func GetFlavor(name string, version *v1.VersionedApplicationSpec) *v1.Flavor {
+	flavor, ok := version.Flavors[name]
+	if !ok {
+		panic(fmt.Errorf("no flavor %q on version %s", name, version.Version))
+	}
+	return flavor
+}